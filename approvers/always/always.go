@@ -1,12 +1,8 @@
 package always
 
 import (
-	"strings"
-
 	"github.com/coreos/kapprover/approvers"
-	"k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/client-go/kubernetes/typed/certificates/v1beta1"
-	certificates "k8s.io/client-go/pkg/apis/certificates/v1beta1"
+	"github.com/coreos/kapprover/csr"
 )
 
 const (
@@ -24,60 +20,43 @@ func init() {
 // already.
 type Always struct{}
 
-// Approve approves CSRs in a loop.
-func (*Always) Approve(client v1beta1.CertificateSigningRequestInterface, request *certificates.CertificateSigningRequest) error {
-	condition := certificates.CertificateSigningRequestCondition{
-		Type:    certificates.CertificateApproved,
-		Reason:  "AutoApproved",
-		Message: "Auto approving of all kubelet CSRs is enabled on bootkube",
+// Approve approves the CSR.
+//
+// Retrying on conflicting updates is the controller's job now: it requeues
+// the CSR with backoff when UpdateApproval fails, instead of Approve looping
+// here.
+func (*Always) Approve(client csr.Client, request *csr.Request) error {
+	// There are only two possible conditions (Approved and Denied).
+	// Therefore if the CSR already has a condition, it means that the
+	// request has already been approved or denied, and that we should
+	// ignore the request.
+	if len(request.Conditions) > 0 {
+		return nil
 	}
 
-	for {
-		// Verify that the CSR hasn't been approved or denied already.
-		//
-		// There are only two possible conditions (CertificateApproved and
-		// CertificateDenied). Therefore if the CSR already has a condition,
-		// it means that the request has already been approved or denied, and that
-		// we should ignore the request.
-		if len(request.Status.Conditions) > 0 {
-			return nil
-		}
-
-		// Ensure the CSR has been submitted by a kubelet performing its TLS
-		// bootstrapping by checking the username and the group.
-		if request.Spec.Username != kubeletBootstrapUsername {
-			return nil
-		}
-
-		isKubeletBootstrapGroup := false
-		for _, group := range request.Spec.Groups {
-			if group == kubeletBootstrapGroup {
-				isKubeletBootstrapGroup = true
-				break
-			}
-		}
-		if !isKubeletBootstrapGroup {
-			return nil
-		}
-
-		// Approve the CSR.
-		request.Status.Conditions = append(request.Status.Conditions, condition)
-
-		// Submit the updated CSR.
-		if _, err := client.UpdateApproval(request); err != nil {
-			if strings.Contains(err.Error(), "the object has been modified") {
-				// The CSR might have been updated by a third-party, retry until we
-				// succeed.
-				request, err = client.Get(request.ObjectMeta.Name, v1.GetOptions{})
-				if err != nil {
-					return err
-				}
-				continue
-			}
+	// Ensure the CSR has been submitted by a kubelet performing its TLS
+	// bootstrapping by checking the username and the group.
+	if request.Username != kubeletBootstrapUsername {
+		return nil
+	}
 
-			return err
+	isKubeletBootstrapGroup := false
+	for _, group := range request.Groups {
+		if group == kubeletBootstrapGroup {
+			isKubeletBootstrapGroup = true
+			break
 		}
-
+	}
+	if !isKubeletBootstrapGroup {
 		return nil
 	}
+
+	request.Conditions = append(request.Conditions, csr.Condition{
+		Type:    csr.Approved,
+		Reason:  "AutoApproved",
+		Message: "Auto approving of all kubelet CSRs is enabled on bootkube",
+	})
+
+	_, err := client.UpdateApproval(request)
+	return err
 }
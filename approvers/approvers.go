@@ -0,0 +1,132 @@
+package approvers
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/coreos/kapprover/csr"
+)
+
+var (
+	approvers = make(map[string]Approver)
+	approverM sync.RWMutex
+)
+
+// Approver represents anything capable of making an approval decision on a
+// CSR.
+type Approver interface {
+	Approve(client csr.Client, request *csr.Request) error
+}
+
+type NamedApprover struct {
+	Name     string
+	Approver Approver
+}
+
+// A slice of named Approvers forming a policy.
+type Approvers []NamedApprover
+
+func (approvers *Approvers) String() string {
+	var b bytes.Buffer
+	for idx, namedApprover := range *approvers {
+		if idx > 0 {
+			b.WriteString(",")
+		}
+		b.WriteString(namedApprover.Name)
+	}
+	return b.String()
+}
+
+func (approvers *Approvers) Set(value string) error {
+	approver, exists := Get(value)
+	if !exists {
+		return errors.New(fmt.Sprintf(
+			"Could not find approver %q, registered approvers: %s",
+			value,
+			strings.Join(List(), ","),
+		))
+	}
+	*approvers = append(*approvers, NamedApprover{Name: value, Approver: approver})
+
+	return nil
+}
+
+// Register makes an Approver available by the provided name.
+//
+// If called twice with the same name, the name is blank, or if the provided
+// Approver is nil, this function panics.
+func Register(name string, a Approver) {
+	approverM.Lock()
+	defer approverM.Unlock()
+
+	if name == "" {
+		panic("approvers: could not register an Approver with an empty name")
+	}
+
+	if a == nil {
+		panic("approvers: could not register a nil Approver")
+	}
+
+	// Enforce lowercase names, so that they can be reliably be found in a map.
+	name = strings.ToLower(name)
+
+	if _, dup := approvers[name]; dup {
+		panic("approvers: RegisterApprover called twice for " + name)
+	}
+
+	approvers[name] = a
+}
+
+// List returns the list of the registered approvers' names.
+func List() []string {
+	approverM.RLock()
+	defer approverM.RUnlock()
+
+	ret := make([]string, 0, len(approvers))
+	for k := range approvers {
+		ret = append(ret, k)
+	}
+
+	return ret
+}
+
+// Unregister removes an Approver with a particular name from the list.
+func Unregister(name string) {
+	approverM.Lock()
+	defer approverM.Unlock()
+	delete(approvers, name)
+}
+
+// Get returns the registered Approver with a provided name.
+func Get(name string) (a Approver, exists bool) {
+	approverM.Lock()
+	defer approverM.Unlock()
+
+	a, exists = approvers[name]
+	return
+}
+
+// Deny marks request as denied, with reason and message recorded on its
+// CertificateDenied condition, and submits the update through client. It is
+// the Deny counterpart to an Approver's Approve: it lets the controller
+// close the loop on a CSR an Inspector rejected, rather than leaving it
+// pending indefinitely.
+//
+// Deny is a no-op if request has already been approved or denied.
+func Deny(client csr.Client, request *csr.Request, reason, message string) error {
+	if len(request.Conditions) > 0 {
+		return nil
+	}
+
+	request.Conditions = append(request.Conditions, csr.Condition{
+		Type:    csr.Denied,
+		Reason:  reason,
+		Message: message,
+	})
+
+	_, err := client.UpdateApproval(request)
+	return err
+}
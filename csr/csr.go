@@ -0,0 +1,74 @@
+// Package csr provides a version-neutral view of a
+// CertificateSigningRequest and a Client abstraction over the
+// certificates.k8s.io v1 and v1beta1 APIs.
+//
+// Approvers and Inspectors are written once against Request and Client; at
+// startup NewClient picks whichever GroupVersion the API server actually
+// serves, so registered plugins never need a variant per Kubernetes version.
+package csr
+
+import (
+	"time"
+
+	"k8s.io/client-go/tools/cache"
+)
+
+// ConditionType mirrors the two terminal conditions a CSR can carry,
+// independent of which GroupVersion defines them.
+type ConditionType string
+
+const (
+	Approved ConditionType = "Approved"
+	Denied   ConditionType = "Denied"
+)
+
+// Condition is a version-neutral CertificateSigningRequestCondition.
+type Condition struct {
+	Type    ConditionType
+	Reason  string
+	Message string
+}
+
+// Request is a version-neutral snapshot of a CertificateSigningRequest: the
+// subset of spec and status fields Approvers and Inspectors need, copied out
+// of whichever GroupVersion the API server serves.
+type Request struct {
+	Name            string
+	ResourceVersion string
+
+	// Raw is the PEM-encoded PKCS#10 certificate request.
+	Raw []byte
+
+	SignerName string
+	Usages     []string
+
+	Username string
+	UID      string
+	Groups   []string
+	Extra    map[string][]string
+
+	Conditions []Condition
+
+	// source is the GroupVersion-specific object this Request was built
+	// from. Client implementations type-assert it back out in
+	// UpdateApproval so a round trip doesn't lose fields the Request
+	// doesn't surface.
+	source interface{}
+}
+
+// Client abstracts the certificates.k8s.io v1 and v1beta1 APIs behind a
+// single version-neutral interface. It is the only thing Approvers and
+// Inspectors need in order to read and decide on a CSR.
+type Client interface {
+	// Get fetches a single CSR by name.
+	Get(name string) (*Request, error)
+
+	// UpdateApproval persists request's Conditions back to the API
+	// server and returns the updated Request.
+	UpdateApproval(request *Request) (*Request, error)
+
+	// NewInformer builds an indexer/controller pair that watches CSRs and
+	// delivers Add and Update events to handler as *Request values. Delete
+	// events are passed to handler unconverted.
+	NewInformer(resyncPeriod time.Duration, handler cache.ResourceEventHandlerFuncs) (cache.Indexer, cache.Controller)
+}
@@ -0,0 +1,19 @@
+package csr
+
+import (
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/kubernetes"
+)
+
+const v1GroupVersion = "certificates.k8s.io/v1"
+
+// NewClient picks a Client implementation by asking the API server, via
+// disco, which GroupVersion of the certificates.k8s.io API it serves. It
+// prefers v1 and falls back to v1beta1 for older clusters.
+func NewClient(client kubernetes.Interface, disco discovery.DiscoveryInterface) (Client, error) {
+	if _, err := disco.ServerResourcesForGroupVersion(v1GroupVersion); err == nil {
+		return NewV1Client(client.CertificatesV1()), nil
+	}
+
+	return NewV1beta1Client(client.CertificatesV1beta1()), nil
+}
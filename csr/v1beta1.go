@@ -0,0 +1,127 @@
+package csr
+
+import (
+	"context"
+	"time"
+
+	certificates "k8s.io/api/certificates/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/client-go/kubernetes/typed/certificates/v1beta1"
+	"k8s.io/client-go/tools/cache"
+)
+
+// v1beta1Client is the Client implementation backed by the
+// certificates.k8s.io/v1beta1 API. It is kept around for clusters running
+// Kubernetes versions old enough to not serve v1.
+type v1beta1Client struct {
+	client v1beta1.CertificatesV1beta1Interface
+}
+
+// NewV1beta1Client returns a Client backed by the certificates.k8s.io/v1beta1
+// API.
+func NewV1beta1Client(client v1beta1.CertificatesV1beta1Interface) Client {
+	return &v1beta1Client{client: client}
+}
+
+func (c *v1beta1Client) Get(name string) (*Request, error) {
+	csr, err := c.client.CertificateSigningRequests().Get(context.TODO(), name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return v1beta1ToRequest(csr), nil
+}
+
+func (c *v1beta1Client) UpdateApproval(request *Request) (*Request, error) {
+	csr := request.source.(*certificates.CertificateSigningRequest)
+	csr.Status.Conditions = requestToV1beta1Conditions(request)
+
+	updated, err := c.client.CertificateSigningRequests().UpdateApproval(context.TODO(), csr.Name, csr, metav1.UpdateOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return v1beta1ToRequest(updated), nil
+}
+
+func (c *v1beta1Client) NewInformer(resyncPeriod time.Duration, handler cache.ResourceEventHandlerFuncs) (cache.Indexer, cache.Controller) {
+	listWatch := cache.NewListWatchFromClient(c.client.RESTClient(), "certificatesigningrequests", metav1.NamespaceAll, fields.Everything())
+
+	return cache.NewIndexerInformer(listWatch, &certificates.CertificateSigningRequest{}, resyncPeriod,
+		cache.ResourceEventHandlerFuncs{
+			AddFunc: func(obj interface{}) {
+				if handler.AddFunc != nil {
+					handler.AddFunc(v1beta1ToRequest(obj.(*certificates.CertificateSigningRequest)))
+				}
+			},
+			UpdateFunc: func(old, new interface{}) {
+				if handler.UpdateFunc != nil {
+					handler.UpdateFunc(v1beta1ToRequest(old.(*certificates.CertificateSigningRequest)), v1beta1ToRequest(new.(*certificates.CertificateSigningRequest)))
+				}
+			},
+			DeleteFunc: func(obj interface{}) {
+				if handler.DeleteFunc != nil {
+					handler.DeleteFunc(obj)
+				}
+			},
+		},
+		cache.Indexers{},
+	)
+}
+
+func v1beta1ToRequest(csr *certificates.CertificateSigningRequest) *Request {
+	usages := make([]string, len(csr.Spec.Usages))
+	for i, usage := range csr.Spec.Usages {
+		usages[i] = string(usage)
+	}
+
+	extra := make(map[string][]string, len(csr.Spec.Extra))
+	for k, v := range csr.Spec.Extra {
+		extra[k] = []string(v)
+	}
+
+	conditions := make([]Condition, len(csr.Status.Conditions))
+	for i, condition := range csr.Status.Conditions {
+		conditions[i] = Condition{
+			Type:    v1beta1ConditionType(condition.Type),
+			Reason:  condition.Reason,
+			Message: condition.Message,
+		}
+	}
+
+	return &Request{
+		Name:            csr.Name,
+		ResourceVersion: csr.ResourceVersion,
+		Raw:             csr.Spec.Request,
+		SignerName:      csr.Spec.SignerName,
+		Usages:          usages,
+		Username:        csr.Spec.Username,
+		UID:             csr.Spec.UID,
+		Groups:          csr.Spec.Groups,
+		Extra:           extra,
+		Conditions:      conditions,
+		source:          csr,
+	}
+}
+
+func v1beta1ConditionType(t certificates.RequestConditionType) ConditionType {
+	if t == certificates.CertificateDenied {
+		return Denied
+	}
+	return Approved
+}
+
+func requestToV1beta1Conditions(request *Request) []certificates.CertificateSigningRequestCondition {
+	conditions := make([]certificates.CertificateSigningRequestCondition, len(request.Conditions))
+	for i, condition := range request.Conditions {
+		conditionType := certificates.CertificateApproved
+		if condition.Type == Denied {
+			conditionType = certificates.CertificateDenied
+		}
+		conditions[i] = certificates.CertificateSigningRequestCondition{
+			Type:    conditionType,
+			Reason:  condition.Reason,
+			Message: condition.Message,
+		}
+	}
+	return conditions
+}
@@ -0,0 +1,181 @@
+package content
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"strings"
+
+	"github.com/coreos/kapprover/csr"
+	"github.com/coreos/kapprover/inspectors"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	kubeletServingSignerName = "kubernetes.io/kubelet-serving"
+	kubeletClientSignerName  = "kubernetes.io/kube-apiserver-client-kubelet"
+
+	nodesGroup     = "system:nodes"
+	nodeUserPrefix = "system:node:"
+)
+
+var (
+	kubeletServingUsages = []string{"digital signature", "key encipherment", "server auth"}
+	kubeletClientUsages  = []string{"digital signature", "key encipherment", "client auth"}
+)
+
+func init() {
+	inspectors.Register("content", &Content{SignerName: kubeletServingSignerName})
+}
+
+// Content is an Inspector that decodes a CSR's PKCS#10 request, verifies its
+// signature, and enforces the CN/organization/SAN/usage rules for a single
+// signerName. Register one instance per signer it should police via
+// "inspector=content=<signerName>"; an instance ignores CSRs for any other
+// signer, so several can be chained together.
+type Content struct {
+	SignerName string
+}
+
+// Configure binds this inspector instance to the signerName given as config.
+func (c *Content) Configure(config string) (inspectors.Inspector, error) {
+	switch config {
+	case kubeletServingSignerName, kubeletClientSignerName:
+		return &Content{SignerName: config}, nil
+	default:
+		return nil, fmt.Errorf("content: unsupported signerName %q, expected %q or %q", config, kubeletServingSignerName, kubeletClientSignerName)
+	}
+}
+
+// Inspect parses and validates request's PKCS#10 certificate request. It
+// returns a human-readable message describing the first violation found, or
+// an empty string if the request satisfies its signer's rules.
+func (c *Content) Inspect(client kubernetes.Interface, request *csr.Request) (string, error) {
+	if request.SignerName != c.SignerName {
+		return "", nil
+	}
+
+	block, _ := pem.Decode(request.Raw)
+	if block == nil || block.Type != "CERTIFICATE REQUEST" {
+		return "unable to decode the PEM-encoded certificate request", nil
+	}
+
+	parsed, err := x509.ParseCertificateRequest(block.Bytes)
+	if err != nil {
+		return fmt.Sprintf("unable to parse the certificate request: %v", err), nil
+	}
+
+	if err := parsed.CheckSignature(); err != nil {
+		return fmt.Sprintf("certificate request signature is invalid: %v", err), nil
+	}
+
+	switch c.SignerName {
+	case kubeletServingSignerName:
+		return c.inspectKubeletServing(client, parsed, request.Usages)
+	case kubeletClientSignerName:
+		return inspectKubeletClient(parsed, request.Usages)
+	default:
+		return "", fmt.Errorf("content: unsupported signerName %q", c.SignerName)
+	}
+}
+
+func (c *Content) inspectKubeletServing(client kubernetes.Interface, parsed *x509.CertificateRequest, usages []string) (string, error) {
+	nodeName, message := nodeNameFromSubject(parsed)
+	if message != "" {
+		return message, nil
+	}
+
+	if len(parsed.EmailAddresses) > 0 || len(parsed.URIs) > 0 {
+		return "kubelet-serving requests may not include email or URI SANs", nil
+	}
+
+	if message := checkUsages(usages, kubeletServingUsages); message != "" {
+		return message, nil
+	}
+
+	node, err := client.CoreV1().Nodes().Get(context.TODO(), nodeName, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return fmt.Sprintf("no Node named %q exists", nodeName), nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	allowedDNS := map[string]bool{nodeName: true}
+	allowedIPs := make(map[string]bool, len(node.Status.Addresses))
+	for _, addr := range node.Status.Addresses {
+		switch addr.Type {
+		case corev1.NodeHostName, corev1.NodeInternalDNS, corev1.NodeExternalDNS:
+			allowedDNS[addr.Address] = true
+		case corev1.NodeInternalIP, corev1.NodeExternalIP:
+			allowedIPs[addr.Address] = true
+		}
+	}
+
+	for _, dns := range parsed.DNSNames {
+		if !allowedDNS[dns] {
+			return fmt.Sprintf("DNS SAN %q does not match node %q", dns, nodeName), nil
+		}
+	}
+	for _, ip := range parsed.IPAddresses {
+		if !allowedIPs[ip.String()] {
+			return fmt.Sprintf("IP SAN %q does not match node %q", ip, nodeName), nil
+		}
+	}
+
+	return "", nil
+}
+
+func inspectKubeletClient(parsed *x509.CertificateRequest, usages []string) (string, error) {
+	if _, message := nodeNameFromSubject(parsed); message != "" {
+		return message, nil
+	}
+
+	if len(parsed.DNSNames) > 0 || len(parsed.IPAddresses) > 0 || len(parsed.EmailAddresses) > 0 || len(parsed.URIs) > 0 {
+		return "kube-apiserver-client-kubelet requests may not include SANs", nil
+	}
+
+	if message := checkUsages(usages, kubeletClientUsages); message != "" {
+		return message, nil
+	}
+
+	return "", nil
+}
+
+// nodeNameFromSubject validates the common organization/CN rules shared by
+// the kubelet-serving and kube-apiserver-client-kubelet signers and, on
+// success, returns the node name encoded in the common name.
+func nodeNameFromSubject(parsed *x509.CertificateRequest) (nodeName string, message string) {
+	if len(parsed.Subject.Organization) != 1 || parsed.Subject.Organization[0] != nodesGroup {
+		return "", fmt.Sprintf("certificate request organization must be %q", nodesGroup)
+	}
+
+	if !strings.HasPrefix(parsed.Subject.CommonName, nodeUserPrefix) {
+		return "", fmt.Sprintf("certificate request common name must be of the form %q", nodeUserPrefix+"<nodeName>")
+	}
+
+	return strings.TrimPrefix(parsed.Subject.CommonName, nodeUserPrefix), ""
+}
+
+// checkUsages rejects any usage in got that isn't in want. got is allowed to
+// be a strict subset of want: an ECDSA-backed kubelet, for instance, omits
+// "key encipherment" entirely, and that's not a violation.
+func checkUsages(got []string, want []string) string {
+	wantSet := make(map[string]bool, len(want))
+	for _, usage := range want {
+		wantSet[usage] = true
+	}
+
+	for _, usage := range got {
+		if !wantSet[usage] {
+			return fmt.Sprintf("usages %v are not limited to the allowed usages %v for this signer", got, want)
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,156 @@
+package content
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"net"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestCheckUsages(t *testing.T) {
+	want := []string{"digital signature", "key encipherment", "server auth"}
+
+	tests := []struct {
+		name   string
+		got    []string
+		reject bool
+	}{
+		{name: "exact match", got: []string{"digital signature", "key encipherment", "server auth"}},
+		{name: "ECDSA subset without key encipherment", got: []string{"digital signature", "server auth"}},
+		{name: "unexpected usage", got: []string{"digital signature", "server auth", "client auth"}, reject: true},
+		{name: "empty", got: nil},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			message := checkUsages(test.got, want)
+			if test.reject && message == "" {
+				t.Fatalf("checkUsages(%v, %v) = %q, want a rejection message", test.got, want, message)
+			}
+			if !test.reject && message != "" {
+				t.Fatalf("checkUsages(%v, %v) = %q, want no rejection", test.got, want, message)
+			}
+		})
+	}
+}
+
+func TestNodeNameFromSubject(t *testing.T) {
+	tests := []struct {
+		name    string
+		subject pkix.Name
+		want    string
+		reject  bool
+	}{
+		{
+			name:    "valid node identity",
+			subject: pkix.Name{Organization: []string{nodesGroup}, CommonName: "system:node:node-1"},
+			want:    "node-1",
+		},
+		{
+			name:    "wrong organization",
+			subject: pkix.Name{Organization: []string{"system:masters"}, CommonName: "system:node:node-1"},
+			reject:  true,
+		},
+		{
+			name:    "wrong common name prefix",
+			subject: pkix.Name{Organization: []string{nodesGroup}, CommonName: "node-1"},
+			reject:  true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			nodeName, message := nodeNameFromSubject(&x509.CertificateRequest{Subject: test.subject})
+			if test.reject && message == "" {
+				t.Fatalf("nodeNameFromSubject(%+v) returned no rejection, want one", test.subject)
+			}
+			if !test.reject {
+				if message != "" {
+					t.Fatalf("nodeNameFromSubject(%+v) = %q, want no rejection", test.subject, message)
+				}
+				if nodeName != test.want {
+					t.Fatalf("nodeNameFromSubject(%+v) = %q, want %q", test.subject, nodeName, test.want)
+				}
+			}
+		})
+	}
+}
+
+// parseRequest builds a self-signed PKCS#10 request for subject with the
+// given SAN DNS names/IPs and returns it parsed, ready for inspectKubeletServing.
+func parseRequest(t *testing.T, subject pkix.Name, dnsNames []string, ips []net.IP) *x509.CertificateRequest {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generating key: %v", err)
+	}
+
+	template := &x509.CertificateRequest{
+		Subject:     subject,
+		DNSNames:    dnsNames,
+		IPAddresses: ips,
+	}
+
+	der, err := x509.CreateCertificateRequest(rand.Reader, template, key)
+	if err != nil {
+		t.Fatalf("creating certificate request: %v", err)
+	}
+
+	parsed, err := x509.ParseCertificateRequest(der)
+	if err != nil {
+		t.Fatalf("parsing certificate request: %v", err)
+	}
+	return parsed
+}
+
+func TestInspectKubeletServing(t *testing.T) {
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "node-1"},
+		Status: corev1.NodeStatus{
+			Addresses: []corev1.NodeAddress{
+				{Type: corev1.NodeInternalIP, Address: "10.0.0.1"},
+			},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		nodeName string
+		dnsNames []string
+		ips      []net.IP
+		reject   bool
+	}{
+		{name: "matching DNS and IP SANs", nodeName: "node-1", dnsNames: []string{"node-1"}, ips: []net.IP{net.ParseIP("10.0.0.1")}},
+		{name: "SAN does not match node", nodeName: "node-1", dnsNames: []string{"other-host"}, reject: true},
+		{name: "node does not exist", nodeName: "missing-node", reject: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			client := fake.NewSimpleClientset(node)
+
+			subject := pkix.Name{Organization: []string{nodesGroup}, CommonName: nodeUserPrefix + test.nodeName}
+			parsed := parseRequest(t, subject, test.dnsNames, test.ips)
+
+			c := &Content{SignerName: kubeletServingSignerName}
+			message, err := c.inspectKubeletServing(client, parsed, kubeletServingUsages)
+			if err != nil {
+				t.Fatalf("inspectKubeletServing: %v", err)
+			}
+			if test.reject && message == "" {
+				t.Fatalf("inspectKubeletServing() returned no rejection, want one")
+			}
+			if !test.reject && message != "" {
+				t.Fatalf("inspectKubeletServing() = %q, want no rejection", message)
+			}
+		})
+	}
+}
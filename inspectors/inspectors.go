@@ -7,7 +7,8 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
-	certificates "k8s.io/api/certificates/v1beta1"
+
+	"github.com/coreos/kapprover/csr"
 	"k8s.io/client-go/kubernetes"
 )
 
@@ -21,7 +22,7 @@ var (
 // to take adverse action, or an error to temporarily fail.
 type Inspector interface {
 	Configure(string) (inspector Inspector, err error)
-	Inspect(kubernetes.Interface, *certificates.CertificateSigningRequest) (message string, err error)
+	Inspect(kubernetes.Interface, *csr.Request) (message string, err error)
 }
 
 type NamedInspector struct {
@@ -0,0 +1,168 @@
+package nodelifecycle
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/coreos/kapprover/csr"
+	"github.com/coreos/kapprover/inspectors"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	kubeletServingSignerName = "kubernetes.io/kubelet-serving"
+	nodeUserPrefix           = "system:node:"
+)
+
+// Mode selects how NodeLifecycle decides that a node identity is legitimate.
+type Mode int
+
+const (
+	// ModeExistingNode requires a Node object to already exist (the
+	// client-cert renewal path).
+	ModeExistingNode Mode = iota
+	// ModeMachine requires a Machine/BYOHost-style custom resource to
+	// exist in a configurable namespace (the bootstrapping path).
+	ModeMachine
+	// ModeEither accepts either of the above.
+	ModeEither
+)
+
+func init() {
+	inspectors.Register("nodelifecycle", &NodeLifecycle{Mode: ModeExistingNode})
+}
+
+// NodeLifecycle is an Inspector that, for kubelet-serving CSRs, requires
+// that the requesting node identity is backed by either an existing Node
+// object or a Machine/BYOHost-style custom resource, depending on Mode. This
+// prevents an attacker who obtains bootstrap credentials from minting
+// serving certificates for arbitrary node names.
+type NodeLifecycle struct {
+	Mode Mode
+
+	// Group, Version and Resource identify the Machine-style custom
+	// resource to check for in ModeMachine and ModeEither.
+	Group     string
+	Version   string
+	Resource  string
+	Namespace string
+}
+
+// Configure parses one of:
+//
+//	existing-node
+//	machine=<group/version/resource>[:namespace]
+//	either=<group/version/resource>[:namespace]
+func (n *NodeLifecycle) Configure(config string) (inspectors.Inspector, error) {
+	mode, rest := config, ""
+	if idx := strings.Index(config, "="); idx >= 0 {
+		mode, rest = config[:idx], config[idx+1:]
+	}
+
+	switch mode {
+	case "existing-node":
+		if rest != "" {
+			return nil, fmt.Errorf("nodelifecycle: existing-node takes no config")
+		}
+		return &NodeLifecycle{Mode: ModeExistingNode}, nil
+
+	case "machine", "either":
+		group, version, resource, namespace, err := parseMachineConfig(rest)
+		if err != nil {
+			return nil, err
+		}
+		configured := &NodeLifecycle{
+			Mode:      ModeMachine,
+			Group:     group,
+			Version:   version,
+			Resource:  resource,
+			Namespace: namespace,
+		}
+		if mode == "either" {
+			configured.Mode = ModeEither
+		}
+		return configured, nil
+
+	default:
+		return nil, fmt.Errorf("nodelifecycle: unknown mode %q", mode)
+	}
+}
+
+func parseMachineConfig(config string) (group, version, resource, namespace string, err error) {
+	gvr := config
+	if idx := strings.LastIndex(config, ":"); idx >= 0 {
+		gvr, namespace = config[:idx], config[idx+1:]
+	}
+
+	parts := strings.Split(gvr, "/")
+	if len(parts) != 3 {
+		return "", "", "", "", fmt.Errorf("nodelifecycle: expected group/version/resource, got %q", gvr)
+	}
+
+	return parts[0], parts[1], parts[2], namespace, nil
+}
+
+// Inspect requires that a kubelet-serving CSR's node identity is backed by
+// an existing Node or Machine-style resource, per Mode.
+func (n *NodeLifecycle) Inspect(client kubernetes.Interface, request *csr.Request) (string, error) {
+	if request.SignerName != kubeletServingSignerName {
+		return "", nil
+	}
+
+	if !strings.HasPrefix(request.Username, nodeUserPrefix) {
+		return fmt.Sprintf("requester %q is not a node identity", request.Username), nil
+	}
+	nodeName := strings.TrimPrefix(request.Username, nodeUserPrefix)
+
+	nodeExists, err := n.nodeExists(client, nodeName)
+	if err != nil {
+		return "", err
+	}
+
+	if n.Mode == ModeExistingNode || (n.Mode == ModeEither && nodeExists) {
+		if !nodeExists {
+			return fmt.Sprintf("no Node named %q exists", nodeName), nil
+		}
+		return "", nil
+	}
+
+	machineExists, err := n.machineExists(client, nodeName)
+	if err != nil {
+		return "", err
+	}
+	if !machineExists {
+		return fmt.Sprintf("no %s named %q exists in namespace %q", n.Resource, nodeName, n.Namespace), nil
+	}
+	return "", nil
+}
+
+func (n *NodeLifecycle) nodeExists(client kubernetes.Interface, name string) (bool, error) {
+	_, err := client.CoreV1().Nodes().Get(context.TODO(), name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (n *NodeLifecycle) machineExists(client kubernetes.Interface, name string) (bool, error) {
+	path := fmt.Sprintf("/apis/%s/%s/namespaces/%s/%s/%s", n.Group, n.Version, n.Namespace, n.Resource, name)
+	if n.Namespace == "" {
+		path = fmt.Sprintf("/apis/%s/%s/%s/%s", n.Group, n.Version, n.Resource, name)
+	}
+
+	err := client.Discovery().RESTClient().Get().AbsPath(path).Do(context.TODO()).Error()
+	if apierrors.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
@@ -0,0 +1,114 @@
+package nodelifecycle
+
+import (
+	"testing"
+
+	"github.com/coreos/kapprover/csr"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestConfigure(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  string
+		want    *NodeLifecycle
+		wantErr bool
+	}{
+		{name: "existing-node", config: "existing-node", want: &NodeLifecycle{Mode: ModeExistingNode}},
+		{name: "existing-node with config", config: "existing-node=foo", wantErr: true},
+		{
+			name:   "machine",
+			config: "machine=infrastructure.cluster.x-k8s.io/v1beta1/machines:kube-system",
+			want: &NodeLifecycle{
+				Mode:      ModeMachine,
+				Group:     "infrastructure.cluster.x-k8s.io",
+				Version:   "v1beta1",
+				Resource:  "machines",
+				Namespace: "kube-system",
+			},
+		},
+		{
+			name:   "either",
+			config: "either=infrastructure.cluster.x-k8s.io/v1beta1/machines:kube-system",
+			want: &NodeLifecycle{
+				Mode:      ModeEither,
+				Group:     "infrastructure.cluster.x-k8s.io",
+				Version:   "v1beta1",
+				Resource:  "machines",
+				Namespace: "kube-system",
+			},
+		},
+		{name: "machine missing gvr parts", config: "machine=v1beta1/machines", wantErr: true},
+		{name: "unknown mode", config: "bogus", wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			n := &NodeLifecycle{}
+			got, err := n.Configure(test.config)
+			if test.wantErr {
+				if err == nil {
+					t.Fatalf("Configure(%q) returned no error, want one", test.config)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Configure(%q): %v", test.config, err)
+			}
+			if *got.(*NodeLifecycle) != *test.want {
+				t.Fatalf("Configure(%q) = %+v, want %+v", test.config, got, test.want)
+			}
+		})
+	}
+}
+
+func TestInspectModeExistingNode(t *testing.T) {
+	node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-1"}}
+
+	tests := []struct {
+		name     string
+		username string
+		reject   bool
+	}{
+		{name: "node exists", username: nodeUserPrefix + "node-1"},
+		{name: "node does not exist", username: nodeUserPrefix + "node-2", reject: true},
+		{name: "requester is not a node identity", username: "some-user", reject: true},
+	}
+
+	n := &NodeLifecycle{Mode: ModeExistingNode}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			client := fake.NewSimpleClientset(node)
+			request := &csr.Request{SignerName: kubeletServingSignerName, Username: test.username}
+
+			message, err := n.Inspect(client, request)
+			if err != nil {
+				t.Fatalf("Inspect: %v", err)
+			}
+			if test.reject && message == "" {
+				t.Fatalf("Inspect() returned no rejection, want one")
+			}
+			if !test.reject && message != "" {
+				t.Fatalf("Inspect() = %q, want no rejection", message)
+			}
+		})
+	}
+}
+
+func TestInspectIgnoresOtherSigners(t *testing.T) {
+	n := &NodeLifecycle{Mode: ModeExistingNode}
+	client := fake.NewSimpleClientset()
+
+	request := &csr.Request{SignerName: "kubernetes.io/kube-apiserver-client-kubelet", Username: nodeUserPrefix + "node-1"}
+	message, err := n.Inspect(client, request)
+	if err != nil {
+		t.Fatalf("Inspect: %v", err)
+	}
+	if message != "" {
+		t.Fatalf("Inspect() = %q, want no rejection for a signer this instance doesn't police", message)
+	}
+}
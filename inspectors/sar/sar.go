@@ -0,0 +1,115 @@
+package sar
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/coreos/kapprover/csr"
+	"github.com/coreos/kapprover/inspectors"
+
+	authorization "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+func init() {
+	inspectors.Register("sar", &SAR{
+		Group:       "certificates.k8s.io",
+		Resource:    "certificatesigningrequests",
+		Subresource: "selfnodeclient",
+		Verb:        "create",
+	})
+}
+
+// SAR is an Inspector that authorizes the CSR requester with a
+// SubjectAccessReview before letting the request reach an Approver. It lets
+// operators delegate the "who may request what kind of certificate" policy
+// to RBAC instead of hard-coding a username or group into an Approver.
+type SAR struct {
+	Group       string
+	Resource    string
+	Subresource string
+	Verb        string
+}
+
+// Configure overrides the default group/resource/subresource/verb with a
+// comma-separated list of key=value pairs, e.g.
+// "resource=certificatesigningrequests,subresource=selfnodeclient".
+func (s *SAR) Configure(config string) (inspectors.Inspector, error) {
+	configured := *s
+
+	for _, pair := range strings.Split(config, ",") {
+		if pair == "" {
+			continue
+		}
+
+		kv := strings.SplitN(pair, "=", 2)
+		if len(kv) != 2 {
+			return nil, fmt.Errorf("sar: invalid config %q, expected key=value", pair)
+		}
+
+		switch kv[0] {
+		case "group":
+			configured.Group = kv[1]
+		case "resource":
+			configured.Resource = kv[1]
+		case "subresource":
+			configured.Subresource = kv[1]
+		case "verb":
+			configured.Verb = kv[1]
+		default:
+			return nil, fmt.Errorf("sar: unknown config key %q", kv[0])
+		}
+	}
+
+	return &configured, nil
+}
+
+// Inspect issues a SubjectAccessReview impersonating the CSR's requester and
+// rejects the CSR if the review disallows it. An error from the API server
+// is returned as-is so the caller retries rather than treating it as a
+// rejection.
+func (s *SAR) Inspect(client kubernetes.Interface, request *csr.Request) (string, error) {
+	extra := make(map[string]authorization.ExtraValue, len(request.Extra))
+	for k, v := range request.Extra {
+		extra[k] = authorization.ExtraValue(v)
+	}
+
+	review := &authorization.SubjectAccessReview{
+		Spec: authorization.SubjectAccessReviewSpec{
+			User:   request.Username,
+			UID:    request.UID,
+			Groups: request.Groups,
+			Extra:  extra,
+			ResourceAttributes: &authorization.ResourceAttributes{
+				Group:       s.Group,
+				Resource:    s.Resource,
+				Subresource: s.Subresource,
+				Verb:        s.Verb,
+			},
+		},
+	}
+
+	result, err := client.AuthorizationV1().SubjectAccessReviews().Create(context.TODO(), review, metav1.CreateOptions{})
+	if err != nil {
+		return "", err
+	}
+
+	if !result.Status.Allowed {
+		return fmt.Sprintf("%s is not allowed to %s %s", request.Username, s.Verb, resourceDescription(s)), nil
+	}
+
+	return "", nil
+}
+
+func resourceDescription(s *SAR) string {
+	resource := s.Resource
+	if s.Subresource != "" {
+		resource = resource + "/" + s.Subresource
+	}
+	if s.Group != "" {
+		resource = resource + "." + s.Group
+	}
+	return resource
+}
@@ -0,0 +1,84 @@
+package sar
+
+import (
+	"testing"
+
+	"github.com/coreos/kapprover/csr"
+
+	authorization "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clienttesting "k8s.io/client-go/testing"
+
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestConfigure(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  string
+		want    SAR
+		wantErr bool
+	}{
+		{
+			name:   "override resource and subresource",
+			config: "resource=certificatesigningrequests,subresource=selfnodeclient",
+			want:   SAR{Group: "g", Resource: "certificatesigningrequests", Subresource: "selfnodeclient", Verb: "v"},
+		},
+		{name: "invalid pair", config: "resource", wantErr: true},
+		{name: "unknown key", config: "bogus=x", wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			base := &SAR{Group: "g", Resource: "r", Subresource: "s", Verb: "v"}
+			got, err := base.Configure(test.config)
+			if test.wantErr {
+				if err == nil {
+					t.Fatalf("Configure(%q) returned no error, want one", test.config)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Configure(%q): %v", test.config, err)
+			}
+			if *got.(*SAR) != test.want {
+				t.Fatalf("Configure(%q) = %+v, want %+v", test.config, got, test.want)
+			}
+		})
+	}
+}
+
+func TestInspect(t *testing.T) {
+	tests := []struct {
+		name    string
+		allowed bool
+		reject  bool
+	}{
+		{name: "allowed", allowed: true},
+		{name: "disallowed", allowed: false, reject: true},
+	}
+
+	s := &SAR{Group: "certificates.k8s.io", Resource: "certificatesigningrequests", Subresource: "selfnodeclient", Verb: "create"}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			client := fake.NewSimpleClientset()
+			client.PrependReactor("create", "subjectaccessreviews", func(action clienttesting.Action) (bool, runtime.Object, error) {
+				review := action.(clienttesting.CreateAction).GetObject().(*authorization.SubjectAccessReview)
+				review.Status.Allowed = test.allowed
+				return true, review, nil
+			})
+
+			message, err := s.Inspect(client, &csr.Request{Username: "system:node:node-1"})
+			if err != nil {
+				t.Fatalf("Inspect: %v", err)
+			}
+			if test.reject && message == "" {
+				t.Fatalf("Inspect() returned no rejection, want one")
+			}
+			if !test.reject && message != "" {
+				t.Fatalf("Inspect() = %q, want no rejection", message)
+			}
+		})
+	}
+}
@@ -0,0 +1,224 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/coreos/kapprover/approvers"
+	_ "github.com/coreos/kapprover/approvers/always"
+	"github.com/coreos/kapprover/csr"
+	"github.com/coreos/kapprover/inspectors"
+	_ "github.com/coreos/kapprover/inspectors/content"
+	_ "github.com/coreos/kapprover/inspectors/nodelifecycle"
+	_ "github.com/coreos/kapprover/inspectors/sar"
+
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/util/workqueue"
+)
+
+var (
+	kubeconfig    = flag.String("kubeconfig", "", "Path to a kubeconfig file. Leave empty to use the in-cluster config.")
+	denyReason    = flag.String("deny-reason", "", "If set, deny (rather than skip) CSRs rejected by an inspector, using this as the condition's Reason.")
+	approverList  approvers.Approvers
+	inspectorList inspectors.Inspectors
+)
+
+func init() {
+	flag.Var(&approverList, "approver", "Approver to use, by name. May be repeated.")
+	flag.Var(&inspectorList, "inspector", "Inspector to use, of the form name[=config]. May be repeated.")
+}
+
+func main() {
+	flag.Parse()
+
+	if len(approverList) == 0 {
+		log.Fatal("at least one -approver must be specified")
+	}
+
+	config, err := buildConfig(*kubeconfig)
+	if err != nil {
+		log.Fatalf("error building kubeconfig: %v", err)
+	}
+
+	client, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		log.Fatalf("error building kubernetes client: %v", err)
+	}
+
+	csrClient, err := csr.NewClient(client, client.Discovery())
+	if err != nil {
+		log.Fatalf("error negotiating the certificates.k8s.io API version: %v", err)
+	}
+
+	stopCh := make(chan struct{})
+	newController(client, csrClient, approverList, inspectorList).Run(1, stopCh)
+}
+
+func buildConfig(kubeconfig string) (*rest.Config, error) {
+	if kubeconfig == "" {
+		return rest.InClusterConfig()
+	}
+	return clientcmd.BuildConfigFromFlags("", kubeconfig)
+}
+
+// controller watches CertificateSigningRequests via a SharedInformer and, for
+// every Add or Update event, runs the request through the configured
+// Inspectors and Approvers. Work is dispatched through a rate-limited
+// workqueue so that a CSR which fails transiently (e.g. a conflicting update)
+// is retried with backoff, up to the limit enforced by handleErr.
+//
+// The informer intentionally enqueues every CSR rather than filtering by
+// spec.signerName: which signers matter is a policy decision that already
+// belongs to the configured Inspectors (content and nodelifecycle both
+// ignore CSRs for signers they don't police), so hard-coding a signer list
+// here would duplicate that and make the controller less flexible than the
+// -inspector flags it already supports.
+type controller struct {
+	client     kubernetes.Interface
+	csrClient  csr.Client
+	approvers  approvers.Approvers
+	inspectors inspectors.Inspectors
+
+	indexer  cache.Indexer
+	informer cache.Controller
+	queue    workqueue.RateLimitingInterface
+}
+
+func newController(client kubernetes.Interface, csrClient csr.Client, a approvers.Approvers, i inspectors.Inspectors) *controller {
+	c := &controller{
+		client:     client,
+		csrClient:  csrClient,
+		approvers:  a,
+		inspectors: i,
+		queue:      workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter()),
+	}
+
+	c.indexer, c.informer = csrClient.NewInformer(0, cache.ResourceEventHandlerFuncs{
+		AddFunc:    c.enqueue,
+		UpdateFunc: func(old, new interface{}) { c.enqueue(new) },
+	})
+
+	return c
+}
+
+func (c *controller) enqueue(obj interface{}) {
+	c.queue.Add(obj.(*csr.Request).Name)
+}
+
+// Run starts the informer, waits for its cache to sync, then processes items
+// from the workqueue with the given number of workers until stopCh is
+// closed.
+func (c *controller) Run(workers int, stopCh chan struct{}) {
+	defer utilruntime.HandleCrash()
+	defer c.queue.ShutDown()
+
+	log.Print("starting CSR controller")
+
+	go c.informer.Run(stopCh)
+
+	if !cache.WaitForCacheSync(stopCh, c.informer.HasSynced) {
+		utilruntime.HandleError(fmt.Errorf("timed out waiting for the CSR cache to sync"))
+		return
+	}
+
+	for i := 0; i < workers; i++ {
+		go wait.Until(c.runWorker, time.Second, stopCh)
+	}
+
+	<-stopCh
+	log.Print("stopping CSR controller")
+}
+
+func (c *controller) runWorker() {
+	for c.processNextItem() {
+	}
+}
+
+func (c *controller) processNextItem() bool {
+	key, quit := c.queue.Get()
+	if quit {
+		return false
+	}
+	defer c.queue.Done(key)
+
+	c.handleErr(c.sync(key.(string)), key)
+	return true
+}
+
+// handleErr requeues key with backoff on error, up to a small number of
+// attempts, then gives up and logs the failure rather than retrying forever.
+func (c *controller) handleErr(err error, key interface{}) {
+	if err == nil {
+		c.queue.Forget(key)
+		return
+	}
+
+	if c.queue.NumRequeues(key) < 5 {
+		log.Printf("error syncing CSR %q, retrying: %v", key, err)
+		c.queue.AddRateLimited(key)
+		return
+	}
+
+	c.queue.Forget(key)
+	utilruntime.HandleError(err)
+	log.Printf("dropping CSR %q from the queue: %v", key, err)
+}
+
+// sync runs a single CSR through the configured Inspectors and Approvers. An
+// error return causes the caller to requeue the CSR for a retry.
+func (c *controller) sync(key string) error {
+	_, exists, err := c.indexer.GetByKey(key)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		// The CSR was deleted before we got to it; nothing to do.
+		return nil
+	}
+
+	// The indexer's store holds the raw GroupVersion-specific objects fed
+	// to it by NewInformer, not *csr.Request, so fetch a version-neutral
+	// copy through the Client instead of reading the indexer's object.
+	request, err := c.csrClient.Get(key)
+	if err != nil {
+		return err
+	}
+
+	// There are only two possible conditions (Approved and Denied). If the
+	// CSR already has one, it has already been decided and there is
+	// nothing left for us to do.
+	if len(request.Conditions) > 0 {
+		return nil
+	}
+
+	for _, namedInspector := range c.inspectors {
+		message, err := namedInspector.Inspector.Inspect(c.client, request)
+		if err != nil {
+			return fmt.Errorf("inspector %q: %v", namedInspector.Name, err)
+		}
+		if message != "" {
+			full := fmt.Sprintf("rejected by inspector %q: %s", namedInspector.Name, message)
+			log.Printf("CSR %q %s", request.Name, full)
+
+			if *denyReason == "" {
+				return nil
+			}
+			return approvers.Deny(c.csrClient, request, *denyReason, full)
+		}
+	}
+
+	for _, namedApprover := range c.approvers {
+		if err := namedApprover.Approver.Approve(c.csrClient, request); err != nil {
+			return fmt.Errorf("approver %q: %v", namedApprover.Name, err)
+		}
+	}
+
+	return nil
+}